@@ -0,0 +1,306 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// contextKey names values this package stores on the gin context. gin
+// keys are plain strings, so the key is converted with string() when
+// stored and read.
+type contextKey string
+
+// currentUserKey is where RequireAuth stores the authenticated user.
+const currentUserKey contextKey = "currentUser"
+
+const (
+	accessTokenTTL  = 15 * time.Minute
+	refreshTokenTTL = 30 * 24 * time.Hour
+)
+
+// authStore is the slice of *Database that AuthService depends on. It
+// exists so tests can exercise token issuance and validation against an
+// in-memory fake instead of a real database.
+type authStore interface {
+	GetUserByID(id int64) (*User, error)
+	GetUserByEmail(email string) (*User, error)
+	VerifyPassword(userID int64, password string) bool
+	NewTokenID() string
+	StoreRefreshToken(userID int64, jti string, expiresAt time.Time) error
+	IsRefreshTokenRevoked(jti string) (bool, error)
+	RevokeRefreshToken(jti string) error
+	CreatePasswordReset(userID int64, tokenHash string, expiresAt time.Time) error
+	ConsumePasswordReset(tokenHash string) (int64, error)
+	SetPassword(userID int64, newPassword string) error
+	RotateUserHash(userID int64) error
+}
+
+// AuthService issues and validates the JWTs used to authenticate requests.
+type AuthService struct {
+	db             authStore
+	secret         []byte
+	passwordPolicy PasswordPolicy
+	mailer         Mailer
+}
+
+// NewAuthService constructs an AuthService. secret signs and verifies
+// access and refresh tokens and must be kept stable across restarts.
+// mailer delivers forgot-password reset emails.
+func NewAuthService(db *Database, secret []byte, policy PasswordPolicy, mailer Mailer) *AuthService {
+	return &AuthService{db: db, secret: secret, passwordPolicy: policy, mailer: mailer}
+}
+
+// LoginRequest represents the request body for POST /api/v1/auth/login
+type LoginRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+// RefreshRequest represents the request body for POST /api/v1/auth/refresh
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// TokenResponse is returned by login and refresh
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+// authClaims is the payload carried by both access and refresh tokens.
+type authClaims struct {
+	UserID int64  `json:"uid"`
+	Type   string `json:"typ"`
+	jwt.RegisteredClaims
+}
+
+// Login authenticates an email/password pair and issues a token pair
+// @Summary Log in
+// @Description Exchange email and password for an access/refresh token pair
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param credentials body LoginRequest true "Login credentials"
+// @Success 200 {object} TokenResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/v1/auth/login [post]
+func (s *AuthService) Login(c *gin.Context) {
+	var req LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := s.db.GetUserByEmail(req.Email)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+		return
+	}
+
+	if !s.db.VerifyPassword(user.ID, req.Password) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+		return
+	}
+
+	tokens, err := s.issueTokenPair(user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to issue tokens"})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// Refresh exchanges a valid, unrevoked refresh token for a new token pair
+// @Summary Refresh an access token
+// @Description Rotate a refresh token for a new access/refresh token pair
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param refresh body RefreshRequest true "Refresh token"
+// @Success 200 {object} TokenResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/v1/auth/refresh [post]
+func (s *AuthService) Refresh(c *gin.Context) {
+	var req RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	claims, user, err := s.parseToken(req.RefreshToken, "refresh")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired refresh token"})
+		return
+	}
+
+	revoked, err := s.db.IsRefreshTokenRevoked(claims.ID)
+	if err != nil || revoked {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Refresh token revoked"})
+		return
+	}
+
+	// Rotate: the old refresh token is revoked as soon as it is redeemed.
+	if err := s.db.RevokeRefreshToken(claims.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to rotate refresh token"})
+		return
+	}
+
+	tokens, err := s.issueTokenPair(user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to issue tokens"})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// Logout revokes the caller's refresh token
+// @Summary Log out
+// @Description Revoke a refresh token so it can no longer be used
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param refresh body RefreshRequest true "Refresh token"
+// @Success 204
+// @Failure 401 {object} ErrorResponse
+// @Router /api/v1/auth/logout [post]
+func (s *AuthService) Logout(c *gin.Context) {
+	var req RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	claims, _, err := s.parseToken(req.RefreshToken, "refresh")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid refresh token"})
+		return
+	}
+
+	if err := s.db.RevokeRefreshToken(claims.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke token"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RequireAuth returns middleware that authenticates requests bearing a
+// valid access token and stores the resolved User on the context.
+func (s *AuthService) RequireAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok || token == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Missing bearer token"})
+			return
+		}
+
+		_, user, err := s.parseToken(token, "access")
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			return
+		}
+
+		c.Set(string(currentUserKey), user)
+		c.Next()
+	}
+}
+
+// CurrentUser reads the authenticated user stored by RequireAuth.
+func CurrentUser(c *gin.Context) (*User, bool) {
+	value, ok := c.Get(string(currentUserKey))
+	if !ok {
+		return nil, false
+	}
+	user, ok := value.(*User)
+	return user, ok
+}
+
+// issueTokenPair mints a fresh access/refresh token pair for user and
+// persists the refresh token's jti so it can later be revoked.
+func (s *AuthService) issueTokenPair(user *User) (*TokenResponse, error) {
+	now := time.Now()
+
+	access, err := s.sign(user, "access", now.Add(accessTokenTTL), "")
+	if err != nil {
+		return nil, err
+	}
+
+	refreshID := s.db.NewTokenID()
+	refresh, err := s.sign(user, "refresh", now.Add(refreshTokenTTL), refreshID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.StoreRefreshToken(user.ID, refreshID, now.Add(refreshTokenTTL)); err != nil {
+		return nil, err
+	}
+
+	return &TokenResponse{
+		AccessToken:  access,
+		RefreshToken: refresh,
+		ExpiresIn:    int64(accessTokenTTL.Seconds()),
+	}, nil
+}
+
+// sign mints a JWT of the given type for user. The user's Hash is mixed
+// into the signing key so rotating it invalidates every outstanding token.
+func (s *AuthService) sign(user *User, typ string, expiresAt time.Time, jti string) (string, error) {
+	claims := authClaims{
+		UserID: user.ID,
+		Type:   typ,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(s.signingKey(user))
+}
+
+// parseToken verifies signature, expiry, and token type, then loads the
+// current user by ID so a password rotation invalidates the token even
+// before it expires (the signing key itself changes).
+func (s *AuthService) parseToken(raw, wantType string) (*authClaims, *User, error) {
+	var claims authClaims
+	_, err := jwt.ParseWithClaims(raw, &claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		// The signing key depends on the user's current Hash, so we
+		// must resolve the claimed user before we can verify it.
+		user, err := s.db.GetUserByID(claims.UserID)
+		if err != nil {
+			return nil, err
+		}
+		return s.signingKey(user), nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if claims.Type != wantType {
+		return nil, nil, errors.New("unexpected token type")
+	}
+
+	user, err := s.db.GetUserByID(claims.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &claims, user, nil
+}
+
+// signingKey derives a per-user HMAC key from the service secret and the
+// user's rotating Hash, so changing the password invalidates every token
+// issued under the previous Hash.
+func (s *AuthService) signingKey(user *User) []byte {
+	return append(append([]byte{}, s.secret...), []byte(user.Hash)...)
+}
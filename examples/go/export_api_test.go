@@ -0,0 +1,65 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUserCursor_RoundTrips(t *testing.T) {
+	want := UserCursor{LastID: 42, LastCreatedAt: time.Unix(0, 1234567890).UTC()}
+
+	got, err := decodeUserCursor(encodeUserCursor(want))
+	if err != nil {
+		t.Fatalf("decodeUserCursor: %v", err)
+	}
+
+	if got.LastID != want.LastID || !got.LastCreatedAt.Equal(want.LastCreatedAt) {
+		t.Fatalf("round-trip mismatch: got %+v, want %+v", got, want)
+	}
+}
+
+func TestDecodeUserCursor_RejectsMalformed(t *testing.T) {
+	if _, err := decodeUserCursor("not-a-valid-cursor"); err == nil {
+		t.Fatal("expected an error decoding a malformed cursor")
+	}
+}
+
+func TestPopulateNextCursor_FullPageSetsCursor(t *testing.T) {
+	result := &UserListResponse{Users: []User{
+		{ID: 1, CreatedAt: time.Unix(0, 1)},
+		{ID: 2, CreatedAt: time.Unix(0, 2)},
+	}}
+
+	populateNextCursor(result, 2)
+
+	if result.NextCursor == "" {
+		t.Fatal("expected NextCursor to be set for a full page")
+	}
+	cursor, err := decodeUserCursor(result.NextCursor)
+	if err != nil {
+		t.Fatalf("decodeUserCursor: %v", err)
+	}
+	if cursor.LastID != 2 {
+		t.Fatalf("expected NextCursor to point at the last row's ID, got %d", cursor.LastID)
+	}
+}
+
+func TestPopulateNextCursor_ShortPageLeavesCursorEmpty(t *testing.T) {
+	result := &UserListResponse{Users: []User{{ID: 1}}}
+
+	populateNextCursor(result, 10)
+
+	if result.NextCursor != "" {
+		t.Fatal("expected NextCursor to stay empty for a short page")
+	}
+}
+
+func TestPopulateNextCursor_EmptyLimitZeroDoesNotPanic(t *testing.T) {
+	result := &UserListResponse{Users: nil}
+
+	populateNextCursor(result, 0)
+
+	if result.NextCursor != "" {
+		t.Fatal("expected NextCursor to stay empty when no rows and limit is 0")
+	}
+}
@@ -0,0 +1,249 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// oauthStateTTL bounds how long an OAuth2 login's CSRF state token
+// remains valid, from OAuthLogin's redirect to OAuthCallback.
+const oauthStateTTL = 10 * time.Minute
+
+// ProviderConfig holds the OAuth2 client settings for a single identity
+// provider (e.g. "github" or an OIDC issuer).
+type ProviderConfig struct {
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	RedirectURL  string
+	// AuthorizeURL and TokenURL are required for generic OIDC providers;
+	// well-known providers like "github" use built-in defaults.
+	AuthorizeURL string
+	TokenURL     string
+}
+
+// ProvidersConfig maps a provider name (as used in the route, e.g.
+// "github" or "oidc") to its configuration.
+type ProvidersConfig map[string]ProviderConfig
+
+// Identity links a User to a remote account at an OAuth2/OIDC provider.
+type Identity struct {
+	UserID   int64  `json:"user_id"`
+	Provider string `json:"provider"`
+	RemoteID string `json:"remote_id"`
+}
+
+// remoteProfile is the subset of a provider's user-info response we care
+// about, normalized across providers.
+type remoteProfile struct {
+	RemoteID string
+	Login    string
+	Name     string
+	Email    string
+	Avatar   string
+}
+
+// OAuthLogin redirects the caller to the provider's authorize URL
+// Generates a random state token, persists it server-side, and passes
+// it through the provider so OAuthCallback can reject requests that
+// don't round-trip the same state (CSRF/login-fixation protection).
+// @Summary Start an OAuth2 login
+// @Description Redirect to the identity provider's authorize endpoint
+// @Tags auth
+// @Param provider path string true "Provider name, e.g. github"
+// @Success 302
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/auth/{provider}/login [get]
+func (s *UserService) OAuthLogin(c *gin.Context) {
+	provider := c.Param("provider")
+	cfg, ok := s.providers[provider]
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unknown provider"})
+		return
+	}
+
+	state, err := newResetToken()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start OAuth2 login"})
+		return
+	}
+	if err := s.db.CreateOAuthState(provider, state, time.Now().Add(oauthStateTTL)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start OAuth2 login"})
+		return
+	}
+
+	c.Redirect(http.StatusFound, s.db.BuildAuthorizeURL(cfg, provider, state))
+}
+
+// OAuthCallback exchanges the provider's authorization code, resolves or
+// provisions a local user, and responds with that user. It issues no
+// access or refresh token.
+// Rejects the callback outright if state is missing or doesn't match
+// the one OAuthLogin issued, so a forged callback can't be replayed
+// against a different login attempt.
+// @Summary Complete an OAuth2 login
+// @Description Exchange the authorization code and resolve or provision a user
+// @Tags auth
+// @Param provider path string true "Provider name, e.g. github"
+// @Param code query string true "Authorization code"
+// @Param state query string true "State token issued by the login redirect"
+// @Success 200 {object} User
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/auth/{provider}/callback [get]
+func (s *UserService) OAuthCallback(c *gin.Context) {
+	provider := c.Param("provider")
+	cfg, ok := s.providers[provider]
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unknown provider"})
+		return
+	}
+
+	code := c.Query("code")
+	if code == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing authorization code"})
+		return
+	}
+
+	state := c.Query("state")
+	if state == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing state"})
+		return
+	}
+	if err := s.db.ConsumeOAuthState(provider, state); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired state"})
+		return
+	}
+
+	profile, err := s.db.ExchangeOAuthCode(cfg, provider, code)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to exchange authorization code"})
+		return
+	}
+
+	user, err := s.resolveOrProvisionUser(provider, profile)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to provision user"})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// resolveOrProvisionUser finds the user already linked to (provider,
+// remote ID), or auto-provisions a new local account for them.
+func (s *UserService) resolveOrProvisionUser(provider string, profile *remoteProfile) (*User, error) {
+	user, err := s.db.GetUserByIdentity(provider, profile.RemoteID)
+	switch {
+	case err == nil:
+		return user, nil
+	case err.Error() == "identity not found":
+		// Fall through to auto-provision below.
+	default:
+		return nil, err
+	}
+
+	user, err = s.db.CreateUserFromIdentity(provider, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.LinkIdentity(user.ID, provider, profile.RemoteID); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// AddIdentityRequest represents the request body for POST /api/v1/users/{id}/identities
+type AddIdentityRequest struct {
+	Provider string `json:"provider" binding:"required"`
+	RemoteID string `json:"remote_id" binding:"required"`
+}
+
+// AddIdentity links an additional OAuth2 identity to an existing user
+// Restricted to admins: letting a caller link an arbitrary remote
+// identity to someone else's account would hand them that account the
+// next time its owner logs in via the linked provider.
+// @Summary Link an identity
+// @Description Link an additional OAuth2 identity to a user
+// @Tags users
+// @Security BearerAuth
+// @Accept json
+// @Param id path int true "User ID"
+// @Param identity body AddIdentityRequest true "Identity to link"
+// @Success 201 {object} Identity
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/users/{id}/identities [post]
+func (s *UserService) AddIdentity(c *gin.Context) {
+	caller, ok := CurrentUser(c)
+	if !ok || !caller.Admin {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Admin privileges required"})
+		return
+	}
+
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req AddIdentityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := s.db.LinkIdentity(id, req.Provider, req.RemoteID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to link identity"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, Identity{UserID: id, Provider: req.Provider, RemoteID: req.RemoteID})
+}
+
+// RemoveIdentity unlinks an OAuth2 identity from a user
+// Restricted to admins, matching AddIdentity.
+// @Summary Unlink an identity
+// @Description Remove a linked OAuth2 identity from a user
+// @Tags users
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param provider path string true "Provider name"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/users/{id}/identities/{provider} [delete]
+func (s *UserService) RemoveIdentity(c *gin.Context) {
+	caller, ok := CurrentUser(c)
+	if !ok || !caller.Admin {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Admin privileges required"})
+		return
+	}
+
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+	provider := c.Param("provider")
+
+	if err := s.db.UnlinkIdentity(id, provider); err != nil {
+		if err.Error() == "identity not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Identity not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unlink identity"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,238 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OrgService handles organization and membership operations
+type OrgService struct {
+	db *Database
+}
+
+// NewOrgService constructs an OrgService.
+func NewOrgService(db *Database) *OrgService {
+	return &OrgService{db: db}
+}
+
+// Org represents an organization
+type Org struct {
+	ID      int64  `json:"id"`
+	Slug    string `json:"slug"`
+	Name    string `json:"name"`
+	OwnerID int64  `json:"owner_id"`
+}
+
+// OrgRole identifies a member's level of access within an org.
+type OrgRole string
+
+const (
+	OrgRoleOwner  OrgRole = "owner"
+	OrgRoleAdmin  OrgRole = "admin"
+	OrgRoleMember OrgRole = "member"
+)
+
+// OrgMembership links a User to an Org with a role
+type OrgMembership struct {
+	OrgID  int64   `json:"org_id"`
+	UserID int64   `json:"user_id"`
+	Role   OrgRole `json:"role"`
+}
+
+// OrgSummary is the slim org representation embedded in GetUser responses
+// when ?include=orgs is requested.
+type OrgSummary struct {
+	Slug string  `json:"slug"`
+	Name string  `json:"name"`
+	Role OrgRole `json:"role"`
+}
+
+// CreateOrgRequest represents the request body for POST /api/v1/orgs
+type CreateOrgRequest struct {
+	Slug string `json:"slug" binding:"required,alphanum"`
+	Name string `json:"name" binding:"required"`
+}
+
+// CreateOrg creates a new organization owned by the caller
+// @Summary Create an organization
+// @Description Create a new organization with the caller as owner
+// @Tags orgs
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param org body CreateOrgRequest true "Organization data"
+// @Success 201 {object} Org
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/orgs [post]
+func (s *OrgService) CreateOrg(c *gin.Context) {
+	var req CreateOrgRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	caller, ok := CurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	org, err := s.db.CreateOrg(req.Slug, req.Name, caller.ID)
+	if err != nil {
+		if err.Error() == "org slug taken" {
+			c.JSON(http.StatusConflict, gin.H{"error": "Slug already in use"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create organization"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, org)
+}
+
+// GetOrg retrieves an organization by its slug
+// @Summary Get organization
+// @Description Retrieve an organization by its slug
+// @Tags orgs
+// @Param slug path string true "Organization slug"
+// @Success 200 {object} Org
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/orgs/{slug} [get]
+func (s *OrgService) GetOrg(c *gin.Context) {
+	org, err := s.db.GetOrgBySlug(c.Param("slug"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Organization not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, org)
+}
+
+// ListUserOrgs retrieves the organizations a user belongs to
+// @Summary List a user's organizations
+// @Description Get the organizations a user is a member of
+// @Tags orgs
+// @Param id path int true "User ID"
+// @Success 200 {object} []Org
+// @Failure 400 {object} ErrorResponse
+// @Router /api/v1/users/{id}/orgs [get]
+func (s *OrgService) ListUserOrgs(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	orgs, err := s.db.ListUserOrgs(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve organizations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, orgs)
+}
+
+// AddMemberRequest represents the request body for POST /api/v1/orgs/{slug}/members
+type AddMemberRequest struct {
+	UserID int64   `json:"user_id" binding:"required"`
+	Role   OrgRole `json:"role" binding:"required"`
+}
+
+// AddMember adds a user to an organization
+// Only the org owner or an admin may add members.
+// @Summary Add an organization member
+// @Description Add a user to an organization with a role
+// @Tags orgs
+// @Security BearerAuth
+// @Accept json
+// @Param slug path string true "Organization slug"
+// @Param member body AddMemberRequest true "Member to add"
+// @Success 201 {object} OrgMembership
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/orgs/{slug}/members [post]
+func (s *OrgService) AddMember(c *gin.Context) {
+	slug := c.Param("slug")
+	org, err := s.db.GetOrgBySlug(slug)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Organization not found"})
+		return
+	}
+
+	if !s.callerCanManage(c, org) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the org owner or an admin can manage members"})
+		return
+	}
+
+	var req AddMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := s.db.AddMember(org.ID, req.UserID, req.Role); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add member"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, OrgMembership{OrgID: org.ID, UserID: req.UserID, Role: req.Role})
+}
+
+// RemoveMember removes a user from an organization
+// Only the org owner or an admin may remove members.
+// @Summary Remove an organization member
+// @Description Remove a user from an organization
+// @Tags orgs
+// @Security BearerAuth
+// @Param slug path string true "Organization slug"
+// @Param userId path int true "User ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/orgs/{slug}/members/{userId} [delete]
+func (s *OrgService) RemoveMember(c *gin.Context) {
+	slug := c.Param("slug")
+	org, err := s.db.GetOrgBySlug(slug)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Organization not found"})
+		return
+	}
+
+	if !s.callerCanManage(c, org) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the org owner or an admin can manage members"})
+		return
+	}
+
+	userID, err := strconv.ParseInt(c.Param("userId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	if err := s.db.RemoveMember(org.ID, userID); err != nil {
+		if err.Error() == "membership not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Membership not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove member"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// callerCanManage reports whether the authenticated caller is the org's
+// owner or a platform admin, the only two roles allowed to manage
+// membership.
+func (s *OrgService) callerCanManage(c *gin.Context, org *Org) bool {
+	caller, ok := CurrentUser(c)
+	if !ok {
+		return false
+	}
+	return caller.Admin || caller.ID == org.OwnerID
+}
@@ -0,0 +1,287 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+)
+
+// resetTokenTTL bounds how long a forgot-password or admin-initiated
+// reset token remains redeemable.
+const resetTokenTTL = 1 * time.Hour
+
+// Mailer delivers password-reset notifications out-of-band. Production
+// code supplies an SMTP- or provider-backed implementation; tests can
+// supply a stub.
+type Mailer interface {
+	SendPasswordReset(ctx context.Context, to, token string) error
+}
+
+// PasswordPolicy enforces minimum complexity requirements for new
+// passwords.
+type PasswordPolicy struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+}
+
+// Validate reports the first policy violation found in password, or nil
+// if it satisfies the policy.
+func (p PasswordPolicy) Validate(password string) error {
+	if utf8.RuneCountInString(password) < p.MinLength {
+		return fmt.Errorf("password must be at least %d characters", p.MinLength)
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			hasUpper = true
+		case r >= 'a' && r <= 'z':
+			hasLower = true
+		case r >= '0' && r <= '9':
+			hasDigit = true
+		default:
+			hasSymbol = true
+		}
+	}
+
+	if p.RequireUpper && !hasUpper {
+		return fmt.Errorf("password must contain an uppercase letter")
+	}
+	if p.RequireLower && !hasLower {
+		return fmt.Errorf("password must contain a lowercase letter")
+	}
+	if p.RequireDigit && !hasDigit {
+		return fmt.Errorf("password must contain a digit")
+	}
+	if p.RequireSymbol && !hasSymbol {
+		return fmt.Errorf("password must contain a symbol")
+	}
+
+	return nil
+}
+
+// ChangePasswordRequest represents the request body for
+// POST /api/v1/users/{id}/password
+type ChangePasswordRequest struct {
+	OldPassword string `json:"old_password" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"`
+}
+
+// ChangePassword lets a user change their own password
+// Callers may only change their own password; admins rewriting another
+// user's credentials must go through RequestPasswordReset instead, so
+// that a password change always requires proving knowledge of the old one.
+// @Summary Change your password
+// @Description Change the caller's own password
+// @Tags users
+// @Security BearerAuth
+// @Accept json
+// @Param id path int true "User ID"
+// @Param body body ChangePasswordRequest true "Old and new password"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/v1/users/{id}/password [post]
+func (s *UserService) ChangePassword(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	caller, ok := CurrentUser(c)
+	if !ok || caller.ID != id {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You may only change your own password"})
+		return
+	}
+
+	var req ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !s.db.VerifyPassword(id, req.OldPassword) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Current password is incorrect"})
+		return
+	}
+
+	if err := s.passwordPolicy.Validate(req.NewPassword); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := s.db.SetPassword(id, req.NewPassword); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to change password"})
+		return
+	}
+	// Rotating Hash invalidates every JWT signed before this point.
+	if err := s.db.RotateUserHash(id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to rotate credentials"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RequestPasswordReset lets an admin trigger a one-time reset for another user
+// The new password is never chosen by the admin: a single-use token is
+// emailed to the user out-of-band via the configured Mailer.
+// @Summary Admin-initiated password reset
+// @Description Email the target user a one-time password reset token
+// @Tags users
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 202
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/users/{id}/password/reset [post]
+func (s *UserService) RequestPasswordReset(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	user, err := s.db.GetUserByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	if err := s.issuePasswordReset(c.Request.Context(), user); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send password reset"})
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// issuePasswordReset mints a single-use token, stores only its hash, and
+// emails the raw token to the user via the configured Mailer.
+func (s *UserService) issuePasswordReset(ctx context.Context, user *User) error {
+	token, err := newResetToken()
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.CreatePasswordReset(user.ID, hashResetToken(token), time.Now().Add(resetTokenTTL)); err != nil {
+		return err
+	}
+
+	return s.mailer.SendPasswordReset(ctx, user.Email, token)
+}
+
+// newResetToken generates a cryptographically random, URL-safe token.
+func newResetToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// hashResetToken hashes a reset token before it is persisted, so a
+// database leak doesn't hand out usable tokens.
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// ForgotPasswordRequest represents the request body for
+// POST /api/v1/auth/forgot-password
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ForgotPassword starts the unauthenticated password recovery flow
+// Always responds 202 regardless of whether the email matches an
+// account, so the endpoint can't be used to enumerate registered users.
+// @Summary Request a password reset
+// @Description Email a one-time password reset token if the address is registered
+// @Tags auth
+// @Accept json
+// @Param body body ForgotPasswordRequest true "Account email"
+// @Success 202
+// @Failure 400 {object} ErrorResponse
+// @Router /api/v1/auth/forgot-password [post]
+func (s *AuthService) ForgotPassword(c *gin.Context) {
+	var req ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if user, err := s.db.GetUserByEmail(req.Email); err == nil {
+		token, err := newResetToken()
+		if err == nil {
+			if err := s.db.CreatePasswordReset(user.ID, hashResetToken(token), time.Now().Add(resetTokenTTL)); err == nil {
+				_ = s.mailer.SendPasswordReset(c.Request.Context(), user.Email, token)
+			}
+		}
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// ResetPasswordRequest represents the request body for
+// POST /api/v1/auth/reset-password
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"`
+}
+
+// ResetPassword completes the unauthenticated password recovery flow
+// @Summary Complete a password reset
+// @Description Redeem a one-time reset token to set a new password
+// @Tags auth
+// @Accept json
+// @Param body body ResetPasswordRequest true "Reset token and new password"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Router /api/v1/auth/reset-password [post]
+func (s *AuthService) ResetPassword(c *gin.Context) {
+	var req ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := s.passwordPolicy.Validate(req.NewPassword); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Consume the single-use token only after the new password has
+	// already passed policy, so a client-side validation error doesn't
+	// burn the token and force the user to request another reset.
+	userID, err := s.db.ConsumePasswordReset(hashResetToken(req.Token))
+	if err != nil {
+		c.JSON(http.StatusGone, gin.H{"error": "Reset token is invalid, expired, or already used"})
+		return
+	}
+
+	if err := s.db.SetPassword(userID, req.NewPassword); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reset password"})
+		return
+	}
+	if err := s.db.RotateUserHash(userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to rotate credentials"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
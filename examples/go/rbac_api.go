@@ -0,0 +1,82 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireAdmin returns middleware that rejects requests from callers who
+// are not authenticated admins. It must run after RequireAuth so that
+// CurrentUser is already populated on the context.
+func RequireAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := CurrentUser(c)
+		if !ok || !user.Admin {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Admin privileges required"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// UpdateRoleRequest represents the request body for PATCH /api/v1/users/{id}/role
+type UpdateRoleRequest struct {
+	Admin bool `json:"admin"`
+}
+
+// UpdateRole grants or revokes admin privileges for a user
+// This is split out from UpdateUser so that role changes are auditable
+// independently of ordinary profile edits. Restricted to admins; an
+// admin cannot change their own role.
+// @Summary Change a user's role
+// @Description Grant or revoke admin privileges for a user
+// @Tags users
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param role body UpdateRoleRequest true "Desired role"
+// @Success 200 {object} User
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/users/{id}/role [patch]
+func (s *UserService) UpdateRole(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	caller, ok := CurrentUser(c)
+	if !ok || !caller.Admin {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Admin privileges required"})
+		return
+	}
+	if caller.ID == id {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Cannot change your own role"})
+		return
+	}
+
+	var req UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	admin := req.Admin
+	user, err := s.db.UpdateUser(id, UpdateUserRequest{Admin: &admin})
+	if err != nil {
+		if err.Error() == "user not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update role"})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
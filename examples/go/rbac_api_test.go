@@ -0,0 +1,122 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func rbacTestContext(caller *User, params gin.Params) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPatch, "/", nil)
+	c.Params = params
+	if caller != nil {
+		c.Set(string(currentUserKey), caller)
+	}
+	return c
+}
+
+func TestSelfModificationForbidden_SelfDemote(t *testing.T) {
+	admin := &User{ID: 1, Admin: true}
+	active := false
+	req := UpdateUserRequest{Active: &active}
+
+	if !selfModificationForbidden(admin, admin.ID, req) {
+		t.Fatal("expected an admin deactivating themselves to be forbidden")
+	}
+}
+
+func TestSelfModificationForbidden_OtherUserAllowed(t *testing.T) {
+	admin := &User{ID: 1, Admin: true}
+	active := false
+	req := UpdateUserRequest{Active: &active}
+
+	if selfModificationForbidden(admin, 2, req) {
+		t.Fatal("expected an admin deactivating another user to be allowed")
+	}
+}
+
+func TestSelfModificationForbidden_NoRelevantFields(t *testing.T) {
+	admin := &User{ID: 1, Admin: true}
+	req := UpdateUserRequest{Name: "new name"}
+
+	if selfModificationForbidden(admin, admin.ID, req) {
+		t.Fatal("expected a plain profile edit of self to be allowed")
+	}
+}
+
+func TestStripAdminFieldForNonAdmin_NonAdminCannotEscalate(t *testing.T) {
+	caller := &User{ID: 2, Admin: false}
+	admin := true
+	req := UpdateUserRequest{Admin: &admin}
+
+	stripAdminFieldForNonAdmin(caller, &req)
+
+	if req.Admin != nil {
+		t.Fatal("expected a non-admin caller's Admin field to be stripped")
+	}
+}
+
+func TestStripAdminFieldForNonAdmin_AdminCallerPreserved(t *testing.T) {
+	caller := &User{ID: 2, Admin: true}
+	admin := true
+	req := UpdateUserRequest{Admin: &admin}
+
+	stripAdminFieldForNonAdmin(caller, &req)
+
+	if req.Admin == nil || !*req.Admin {
+		t.Fatal("expected an admin caller's Admin field to be preserved")
+	}
+}
+
+func TestDeleteUser_SelfDeleteForbidden(t *testing.T) {
+	svc := &UserService{}
+	admin := &User{ID: 1, Admin: true}
+
+	c := rbacTestContext(admin, gin.Params{{Key: "id", Value: "1"}})
+	svc.DeleteUser(c)
+
+	w := c.Writer.Status()
+	if w != http.StatusForbidden {
+		t.Fatalf("expected 403 when an admin deletes their own account, got %d", w)
+	}
+}
+
+func TestUpdateRole_SelfRoleChangeForbidden(t *testing.T) {
+	svc := &UserService{}
+	admin := &User{ID: 1, Admin: true}
+
+	c := rbacTestContext(admin, gin.Params{{Key: "id", Value: "1"}})
+	svc.UpdateRole(c)
+
+	if status := c.Writer.Status(); status != http.StatusForbidden {
+		t.Fatalf("expected 403 when an admin changes their own role, got %d", status)
+	}
+}
+
+func TestUpdateRole_NonAdminCallerForbidden(t *testing.T) {
+	svc := &UserService{}
+	caller := &User{ID: 2, Admin: false}
+
+	c := rbacTestContext(caller, gin.Params{{Key: "id", Value: "3"}})
+	svc.UpdateRole(c)
+
+	if status := c.Writer.Status(); status != http.StatusForbidden {
+		t.Fatalf("expected 403 when a non-admin caller changes another user's role, got %d", status)
+	}
+}
+
+func TestUpdateRole_UnauthenticatedCallerForbidden(t *testing.T) {
+	svc := &UserService{}
+
+	c := rbacTestContext(nil, gin.Params{{Key: "id", Value: "3"}})
+	svc.UpdateRole(c)
+
+	if status := c.Writer.Status(); status != http.StatusForbidden {
+		t.Fatalf("expected 403 with no authenticated caller, got %d", status)
+	}
+}
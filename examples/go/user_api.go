@@ -3,22 +3,44 @@ package api
 import (
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 // UserService handles user-related operations
 type UserService struct {
-	db *Database
+	db             *Database
+	providers      ProvidersConfig
+	passwordPolicy PasswordPolicy
+	mailer         Mailer
+}
+
+// NewUserService constructs a UserService. providers configures the
+// OAuth2 identity providers available for login/registration and may be
+// the zero value if OAuth2 login is not in use. mailer delivers
+// admin-initiated password reset emails.
+func NewUserService(db *Database, providers ProvidersConfig, policy PasswordPolicy, mailer Mailer) *UserService {
+	return &UserService{db: db, providers: providers, passwordPolicy: policy, mailer: mailer}
 }
 
 // User represents a user entity
 type User struct {
-	ID      int64        `json:"id"`
-	Name    string       `json:"name"`
-	Email   string       `json:"email"`
-	Active  bool         `json:"active"`
+	ID        int64     `json:"id"`
+	Login     string    `json:"login"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Active    bool      `json:"active"`
+	Admin     bool      `json:"admin"`
+	CreatedAt time.Time `json:"created_at"`
+	// Hash is a per-user random value mixed into the JWT signing key.
+	// Rotating it (e.g. on password change) invalidates every token
+	// issued before the rotation.
+	Hash    string       `json:"-"`
 	Profile *UserProfile `json:"profile,omitempty"`
+	// Orgs is only populated by GetUser when called with ?include=orgs.
+	Orgs []OrgSummary `json:"orgs,omitempty"`
 }
 
 // UserProfile contains additional user information
@@ -40,14 +62,19 @@ type UpdateUserRequest struct {
 	Name   string `json:"name,omitempty"`
 	Email  string `json:"email,omitempty"`
 	Active *bool  `json:"active,omitempty"`
+	// Admin is only honored when the caller is itself an admin; it is
+	// silently dropped from the request otherwise. See UpdateUser.
+	Admin *bool `json:"admin,omitempty"`
 }
 
 // GetUser retrieves a user by their ID
-// This endpoint returns complete user information including profile data
+// This endpoint returns complete user information including profile data.
+// Pass ?include=orgs to embed the user's slim organization list.
 // @Summary Get user by ID
 // @Description Retrieve detailed user information by user identifier
 // @Tags users
 // @Param id path int true "User ID"
+// @Param include query string false "Comma-separated related resources to embed, e.g. orgs"
 // @Success 200 {object} User
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
@@ -66,9 +93,29 @@ func (s *UserService) GetUser(c *gin.Context) {
 		return
 	}
 
+	if includesRelation(c.Query("include"), "orgs") {
+		orgs, err := s.db.ListUserOrgSummaries(id)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load organizations"})
+			return
+		}
+		user.Orgs = orgs
+	}
+
 	c.JSON(http.StatusOK, user)
 }
 
+// includesRelation reports whether name appears in a comma-separated
+// ?include= query value.
+func includesRelation(include, name string) bool {
+	for _, part := range strings.Split(include, ",") {
+		if strings.TrimSpace(part) == name {
+			return true
+		}
+	}
+	return false
+}
+
 // CreateUser creates a new user account
 // Validates the input data and creates a user with default settings
 // @Summary Create new user
@@ -109,7 +156,10 @@ func (s *UserService) CreateUser(c *gin.Context) {
 }
 
 // UpdateUser updates an existing user's information
-// Allows partial updates of user data
+// Allows partial updates of user data. Admin is silently dropped unless
+// the caller is an admin; Active is applied whatever the caller's role.
+// Neither may be used to modify the caller's own account (see
+// selfModificationForbidden).
 // @Summary Update user
 // @Description Update user information with partial data
 // @Tags users
@@ -119,6 +169,7 @@ func (s *UserService) CreateUser(c *gin.Context) {
 // @Param user body UpdateUserRequest true "User update data"
 // @Success 200 {object} User
 // @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Router /api/v1/users/{id} [put]
 func (s *UserService) UpdateUser(c *gin.Context) {
@@ -135,6 +186,13 @@ func (s *UserService) UpdateUser(c *gin.Context) {
 		return
 	}
 
+	caller, _ := CurrentUser(c)
+	if selfModificationForbidden(caller, id, req) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Cannot update your own active or admin status"})
+		return
+	}
+	stripAdminFieldForNonAdmin(caller, &req)
+
 	user, err := s.db.UpdateUser(id, req)
 	if err != nil {
 		if err.Error() == "user not found" {
@@ -148,14 +206,36 @@ func (s *UserService) UpdateUser(c *gin.Context) {
 	c.JSON(http.StatusOK, user)
 }
 
+// selfModificationForbidden reports whether req would change the
+// caller's own Active or Admin status, which is never allowed: an admin
+// cannot deactivate, demote, or otherwise self-modify those fields.
+func selfModificationForbidden(caller *User, targetID int64, req UpdateUserRequest) bool {
+	if req.Active == nil && req.Admin == nil {
+		return false
+	}
+	return caller != nil && caller.ID == targetID
+}
+
+// stripAdminFieldForNonAdmin clears req.Admin unless caller is itself an
+// admin, so a non-admin can never grant or revoke admin rights, even on
+// another user's account.
+func stripAdminFieldForNonAdmin(caller *User, req *UpdateUserRequest) {
+	if req.Admin != nil && (caller == nil || !caller.Admin) {
+		req.Admin = nil
+	}
+}
+
 // DeleteUser removes a user account
-// Soft deletes the user and related data
+// Soft deletes the user and related data. An admin cannot delete their
+// own account, matching the self-protection rule enforced in UpdateUser.
 // @Summary Delete user
 // @Description Soft delete a user account
 // @Tags users
+// @Security BearerAuth
 // @Param id path int true "User ID"
 // @Success 204
 // @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Router /api/v1/users/{id} [delete]
 func (s *UserService) DeleteUser(c *gin.Context) {
@@ -166,6 +246,11 @@ func (s *UserService) DeleteUser(c *gin.Context) {
 		return
 	}
 
+	if caller, ok := CurrentUser(c); ok && caller.ID == id {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Cannot delete your own account"})
+		return
+	}
+
 	err = s.db.SoftDeleteUser(id)
 	if err != nil {
 		if err.Error() == "user not found" {
@@ -180,22 +265,34 @@ func (s *UserService) DeleteUser(c *gin.Context) {
 }
 
 // ListUsers retrieves a paginated list of users
-// Supports filtering and sorting options
+// Supports filtering and sorting options. Restricted to admins; register
+// it behind RequireAuth() and RequireAdmin().
+//
+// Passing ?cursor=<opaque> switches to keyset pagination, which stays
+// fast and stable under concurrent writes; page/limit continue to work
+// as before when no cursor is supplied.
 // @Summary List users
 // @Description Get paginated list of users with filtering
 // @Tags users
+// @Security BearerAuth
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
 // @Param active query bool false "Filter by active status"
 // @Param search query string false "Search in name and email"
+// @Param org query string false "Restrict to members of the given org slug"
+// @Param cursor query string false "Opaque cursor from a previous response's next_cursor"
 // @Success 200 {object} UserListResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Router /api/v1/users [get]
 func (s *UserService) ListUsers(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	limit := intOrDefault(c.DefaultQuery("limit", "10"), 10)
+	if limit <= 0 {
+		limit = 10
+	}
 	search := c.Query("search")
 	activeFilter := c.Query("active")
+	orgSlug := c.Query("org")
 
 	var active *bool
 	if activeFilter != "" {
@@ -206,12 +303,34 @@ func (s *UserService) ListUsers(c *gin.Context) {
 	}
 
 	filters := UserFilters{
-		Page:   page,
-		Limit:  limit,
-		Search: search,
-		Active: active,
+		Limit:   limit,
+		Search:  search,
+		Active:  active,
+		OrgSlug: orgSlug,
+	}
+
+	if rawCursor := c.Query("cursor"); rawCursor != "" {
+		cursor, err := decodeUserCursor(rawCursor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cursor"})
+			return
+		}
+		filters.After = cursor
+
+		result, err := s.db.ListUsersByCursor(filters)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve users"})
+			return
+		}
+
+		populateNextCursor(result, filters.Limit)
+
+		c.JSON(http.StatusOK, result)
+		return
 	}
 
+	filters.Page = intOrDefault(c.DefaultQuery("page", "1"), 1)
+
 	result, err := s.db.ListUsers(filters)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve users"})
@@ -221,21 +340,59 @@ func (s *UserService) ListUsers(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// populateNextCursor sets result.NextCursor when a full page was
+// returned, since a full page means more rows may follow; a short (or
+// empty) page means the result set is exhausted, so NextCursor stays
+// empty. limit is the page size that was requested, not len(result.Users).
+func populateNextCursor(result *UserListResponse, limit int) {
+	if len(result.Users) == 0 || len(result.Users) != limit {
+		return
+	}
+	last := result.Users[len(result.Users)-1]
+	result.NextCursor = encodeUserCursor(UserCursor{LastID: last.ID, LastCreatedAt: last.CreatedAt})
+}
+
+func intOrDefault(raw string, fallback int) int {
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		return fallback
+	}
+	return n
+}
+
 // UserFilters contains filtering options for user list
 type UserFilters struct {
 	Page   int
 	Limit  int
 	Search string
 	Active *bool
+	// OrgSlug, when set, restricts the result to members of that org.
+	OrgSlug string
+	// After, when set, requests the page of results immediately
+	// following this keyset cursor instead of an offset page.
+	After *UserCursor
+}
+
+// UserCursor identifies a position in the (created_at, id) keyset order
+// used by cursor-based pagination.
+type UserCursor struct {
+	LastID        int64
+	LastCreatedAt time.Time
 }
 
 // UserListResponse represents paginated user list response
 type UserListResponse struct {
-	Users      []User `json:"users"`
-	Total      int64  `json:"total"`
-	Page       int    `json:"page"`
-	Limit      int    `json:"limit"`
-	TotalPages int    `json:"total_pages"`
+	Users []User `json:"users"`
+	Total int64  `json:"total"`
+	// Page, Limit, and TotalPages are only populated for offset
+	// pagination; cursor-based responses leave them zero and set
+	// NextCursor instead.
+	Page       int `json:"page,omitempty"`
+	Limit      int `json:"limit"`
+	TotalPages int `json:"total_pages,omitempty"`
+	// NextCursor is the opaque cursor for the following page, empty
+	// when the result set is exhausted.
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // ErrorResponse represents an error response